@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// authenticatedUserResponse is the response shape of GET /2/users/me.
+type authenticatedUserResponse struct {
+	Data struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// getAuthenticatedUserID looks up the numeric user ID of the account
+// authenticated by httpClient. Endpoints like likes, retweets, and
+// following are scoped under /2/users/:id/..., where :id is this value.
+func getAuthenticatedUserID(httpClient *http.Client) (string, error) {
+	req, err := http.NewRequest("GET", "https://api.twitter.com/2/users/me", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("failed to look up authenticated user: HTTP %d - %s", resp.StatusCode, string(body))
+	}
+
+	var parsed authenticatedUserResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse authenticated user response: %w", err)
+	}
+	if parsed.Data.ID == "" {
+		return "", fmt.Errorf("authenticated user response did not include an ID")
+	}
+
+	return parsed.Data.ID, nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bmf-san/go-tweet-cleaner/internal/auth"
+)
+
+var authClientID string
+
+// authCmd groups authentication-related subcommands.
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage authentication",
+	Long:  `Commands for authenticating go-tweet-cleaner against the Twitter API.`,
+}
+
+// authLoginCmd performs an OAuth 2.0 Authorization Code with PKCE login,
+// as an alternative to passing --consumer-key/--consumer-secret/
+// --access-token/--access-token-secret on every command.
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in with OAuth2, so other commands don't need API keys",
+	Long:  `Logs in using Twitter's OAuth 2.0 Authorization Code with PKCE flow and saves the resulting token, so delete, delete-likes, delete-retweets, unfollow, and resume can use it instead of OAuth1 consumer/access keys.`,
+	Run:   runAuthLogin,
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authLoginCmd)
+
+	authLoginCmd.Flags().StringVar(&authClientID, "client-id", "", "OAuth2 Client ID from the Twitter Developer Portal")
+	authLoginCmd.MarkFlagRequired("client-id")
+}
+
+func runAuthLogin(cmd *cobra.Command, args []string) {
+	tok, err := auth.Login(context.Background(), authClientID)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	if err := auth.SaveToken(tok); err != nil {
+		fmt.Printf("Error: failed to save token: %v\n", err)
+		return
+	}
+
+	path, _ := auth.TokenPath()
+	fmt.Printf("Logged in. Token saved to %s\n", path)
+}
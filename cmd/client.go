@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dghubble/oauth1"
+
+	"github.com/bmf-san/go-tweet-cleaner/internal/auth"
+)
+
+// newAuthenticatedClient returns an http.Client authenticated against the
+// Twitter API, preferring a token saved by `auth login` and falling back
+// to the --consumer-key/--consumer-secret/--access-token/
+// --access-token-secret flags.
+func newAuthenticatedClient() (*http.Client, error) {
+	tok, ok, err := auth.LoadToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stored OAuth2 token: %w", err)
+	}
+	if ok {
+		return auth.HTTPClient(context.Background(), tok), nil
+	}
+
+	if consumerKey == "" || consumerSecret == "" || accessToken == "" || accessTokenSecret == "" {
+		return nil, fmt.Errorf("no stored OAuth2 token found; run `go-tweet-cleaner auth login` or pass --consumer-key, --consumer-secret, --access-token, and --access-token-secret")
+	}
+
+	config := oauth1.NewConfig(consumerKey, consumerSecret)
+	token := oauth1.NewToken(accessToken, accessTokenSecret)
+	return config.Client(oauth1.NoContext, token), nil
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import "testing"
+
+// withOAuth1Flags sets and restores the package-level --consumer-key et al.
+// flag variables for the duration of a test.
+func withOAuth1Flags(t *testing.T, key, secret, token, tokenSecret string) {
+	t.Helper()
+
+	origKey, origSecret, origToken, origTokenSecret := consumerKey, consumerSecret, accessToken, accessTokenSecret
+	consumerKey, consumerSecret, accessToken, accessTokenSecret = key, secret, token, tokenSecret
+	t.Cleanup(func() {
+		consumerKey, consumerSecret, accessToken, accessTokenSecret = origKey, origSecret, origToken, origTokenSecret
+	})
+}
+
+func TestNewAuthenticatedClientRequiresCredentials(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	withOAuth1Flags(t, "", "", "", "")
+
+	if _, err := newAuthenticatedClient(); err == nil {
+		t.Fatal("newAuthenticatedClient() error = nil, want an error with no stored token and no OAuth1 flags")
+	}
+}
+
+func TestNewAuthenticatedClientAcceptsOAuth1Flags(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	withOAuth1Flags(t, "key", "secret", "token", "token-secret")
+
+	client, err := newAuthenticatedClient()
+	if err != nil {
+		t.Fatalf("newAuthenticatedClient() error = %v, want nil with all four OAuth1 flags set", err)
+	}
+	if client == nil {
+		t.Fatal("newAuthenticatedClient() returned a nil client")
+	}
+}
+
+func TestNewAuthenticatedClientRejectsPartialOAuth1Flags(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	withOAuth1Flags(t, "key", "secret", "", "")
+
+	if _, err := newAuthenticatedClient(); err == nil {
+		t.Fatal("newAuthenticatedClient() error = nil, want an error when only some OAuth1 flags are set")
+	}
+}
@@ -2,27 +2,50 @@ package cmd
 
 import (
 	"bufio"
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
-	"github.com/dghubble/oauth1"
 	"github.com/spf13/cobra"
+
+	"github.com/bmf-san/go-tweet-cleaner/internal/archive"
+	"github.com/bmf-san/go-tweet-cleaner/internal/store"
 )
 
 var (
 	archivePath string
 	dryRun      bool
 	sortOrder   string
+	offset      int
+
+	keepDays        int
+	beforeDate      string
+	afterDate       string
+	matchPattern    string
+	notMatchPattern string
+	minLikes        int
+	minRetweets     int
+	excludeReplies  bool
+	excludeRetweets bool
+	allowlistPath   string
+
+	statePath string
+
+	concurrency int
+	maxRetries  int
 )
 
+// twitterTimeLayout is the timestamp format used for created_at fields
+// in Twitter archive JSON files.
+const twitterTimeLayout = "Mon Jan 02 15:04:05 -0700 2006"
+
+// archiveDateLayout is the layout accepted by the --before and --after flags.
+const archiveDateLayout = "2006-01-02"
+
 var deleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete tweets",
@@ -32,20 +55,32 @@ var deleteCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(deleteCmd)
-	deleteCmd.Flags().StringVar(&archivePath, "archive", "", "Path to Twitter archive directory")
+	deleteCmd.Flags().StringVar(&archivePath, "archive", "", "Path to a Twitter archive directory or the downloaded .zip")
 	deleteCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only show tweets that would be deleted without actually deleting")
 	deleteCmd.Flags().StringVar(&sortOrder, "sort", "newest", "Sort order: 'newest' (default), 'oldest', or 'original'")
+	deleteCmd.Flags().IntVar(&offset, "offset", 0, "Number of sorted tweets to skip before applying --limit (only applied when --limit is positive)")
 	deleteCmd.MarkFlagRequired("archive")
-}
 
-// TwitterArchiveTweet represents a tweet in the Twitter archive JSON format
-type TwitterArchiveTweet struct {
-	ID        string `json:"id_str"`
-	CreatedAt string `json:"created_at"`
-	Text      string `json:"full_text"`
-	Retweeted bool   `json:"retweeted"`
+	deleteCmd.Flags().IntVar(&keepDays, "keep-days", 0, "Skip tweets newer than N days")
+	deleteCmd.Flags().StringVar(&beforeDate, "before", "", "Only target tweets created before this date (YYYY-MM-DD)")
+	deleteCmd.Flags().StringVar(&afterDate, "after", "", "Only target tweets created after this date (YYYY-MM-DD)")
+	deleteCmd.Flags().StringVar(&matchPattern, "match", "", "Only target tweets whose text matches this regex")
+	deleteCmd.Flags().StringVar(&notMatchPattern, "not-match", "", "Skip tweets whose text matches this regex")
+	deleteCmd.Flags().IntVar(&minLikes, "min-likes", 0, "Skip tweets with fewer than N likes")
+	deleteCmd.Flags().IntVar(&minRetweets, "min-retweets", 0, "Skip tweets with fewer than N retweets")
+	deleteCmd.Flags().BoolVar(&excludeReplies, "exclude-replies", false, "Skip tweets that are replies")
+	deleteCmd.Flags().BoolVar(&excludeRetweets, "exclude-retweets", false, "Skip retweets")
+	deleteCmd.Flags().StringVar(&allowlistPath, "allowlist", "", "Path to a file of tweet IDs (one per line) to never touch")
+
+	deleteCmd.Flags().StringVar(&statePath, "state", "", "Path to a SQLite database file used to track job state across runs")
+
+	deleteCmd.Flags().IntVar(&concurrency, "concurrency", 1, "Number of deletion workers to run in parallel")
+	deleteCmd.Flags().IntVar(&maxRetries, "max-retries", 3, "Maximum number of retries for a transient failure (429 or 5xx)")
 }
 
+// TwitterArchiveTweet represents a tweet in the Twitter archive JSON format.
+type TwitterArchiveTweet = archive.Tweet
+
 // TwitterAPIResponse represents a response from the Twitter API
 type TwitterAPIResponse struct {
 	Data struct {
@@ -91,123 +126,79 @@ func checkTweetExists(client *http.Client, tweetID string) bool {
 	return resp.StatusCode == 200
 }
 
-func runDelete(cmd *cobra.Command, args []string) {
-	// Check for required credentials
-	if consumerKey == "" || consumerSecret == "" || accessToken == "" || accessTokenSecret == "" {
-		fmt.Println("Error: All authentication credentials are required")
-		return
+// alreadyDeletedInStore reports whether st already has tweetID recorded as
+// deleted. A nil store (no --state flag) never short-circuits.
+func alreadyDeletedInStore(st *store.Store, tweetID string) bool {
+	if st == nil {
+		return false
 	}
-
-	// Check that archive path exists
-	archiveInfo, err := os.Stat(archivePath)
-	if os.IsNotExist(err) {
-		fmt.Printf("Error: Archive path %s does not exist\n", archivePath)
-		return
+	rec, ok, err := st.Get(tweetID)
+	if err != nil || !ok {
+		return false
 	}
-	if !archiveInfo.IsDir() {
-		fmt.Printf("Error: Archive path %s is not a directory\n", archivePath)
+	return rec.Status == store.StatusDeleted
+}
+
+// recordStatus upserts tweetID's status into st, if a state store is in use.
+func recordStatus(st *store.Store, tweetID string, status store.Status, reason string) {
+	if st == nil {
 		return
 	}
-
-	// Find tweet data files in the archive
-	var tweetFiles []string
-
-	// Try data/tweets.js (current format)
-	tweetsFile := filepath.Join(archivePath, "data", "tweets.js")
-	if _, err := os.Stat(tweetsFile); err == nil {
-		tweetFiles = append(tweetFiles, tweetsFile)
+	if err := st.Upsert(tweetID, status, reason); err != nil {
+		fmt.Printf("Warning: failed to record state for tweet %s: %v\n", tweetID, err)
 	}
+}
 
-	// Try data/tweets directory (newer format)
-	tweetDataDir := filepath.Join(archivePath, "data", "tweets")
-	if _, err := os.Stat(tweetDataDir); err == nil {
-		files, err := ioutil.ReadDir(tweetDataDir)
-		if err == nil {
-			for _, file := range files {
-				if !file.IsDir() && strings.HasSuffix(file.Name(), ".js") {
-					tweetFiles = append(tweetFiles, filepath.Join(tweetDataDir, file.Name()))
-				}
-			}
-		}
+func runDelete(cmd *cobra.Command, args []string) {
+	httpClient, err := newAuthenticatedClient()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
 	}
 
-	// Try tweet.js (older format)
-	if len(tweetFiles) == 0 {
-		oldFormatFile := filepath.Join(archivePath, "data", "tweet.js")
-		if _, err := os.Stat(oldFormatFile); err == nil {
-			tweetFiles = append(tweetFiles, oldFormatFile)
-		}
+	// Check that archive path exists
+	if _, err := os.Stat(archivePath); os.IsNotExist(err) {
+		fmt.Printf("Error: Archive path %s does not exist\n", archivePath)
+		return
 	}
 
-	if len(tweetFiles) == 0 {
-		fmt.Println("Error: Could not find tweet data files in the archive")
+	// Parse tweet data files in the archive
+	tweets, err := archive.LoadTweets(archivePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
 		return
 	}
 
-	fmt.Printf("Found %d tweet data files in the archive\n", len(tweetFiles))
-
-	// Parse tweet data files and extract tweets
-	var tweets []TwitterArchiveTweet
-
-	for _, file := range tweetFiles {
-		fmt.Printf("Reading %s...\n", file)
-
-		// Read file content
-		content, err := ioutil.ReadFile(file)
-		if err != nil {
-			fmt.Printf("Error reading file %s: %v\n", file, err)
-			continue
-		}
-
-		// Twitter archive files start with a variable assignment like "window.YTD.tweet.part0 = "
-		// We need to remove this prefix to get valid JSON
-		jsonContent := content
-		if bytes.HasPrefix(content, []byte("window.")) {
-			parts := bytes.SplitN(content, []byte("= "), 2)
-			if len(parts) < 2 {
-				fmt.Printf("Error parsing file %s: unexpected format\n", file)
-				continue
-			}
-			jsonContent = parts[1]
-		}
-
-		// Parse JSON
-		var tweetData []struct {
-			Tweet TwitterArchiveTweet `json:"tweet"`
-		}
-
-		err = json.Unmarshal(jsonContent, &tweetData)
-		if err != nil {
-			fmt.Printf("Error parsing JSON from file %s: %v\n", file, err)
-			continue
-		}
+	fmt.Printf("Extracted %d tweets from the archive\n", len(tweets))
 
-		// Extract tweets
-		for _, t := range tweetData {
-			tweets = append(tweets, t.Tweet)
-		}
+	// Apply filters to decide which tweets are actually up for deletion
+	filterOpts, err := buildFilterOptions()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
 	}
 
+	tweets, summary := filterTweets(tweets, filterOpts)
+	printFilterSummary(summary)
+
 	if len(tweets) == 0 {
-		fmt.Println("No tweets found in the archive")
+		fmt.Println("No tweets left after filtering")
 		return
 	}
 
-	fmt.Printf("Extracted %d tweets from the archive\n", len(tweets))
-
 	// Sort tweets by creation date based on sort order
 	switch sortOrder {
 	case "oldest":
 		sort.Slice(tweets, func(i, j int) bool {
-			timeI, _ := time.Parse("Mon Jan 02 15:04:05 -0700 2006", tweets[i].CreatedAt)
-			timeJ, _ := time.Parse("Mon Jan 02 15:04:05 -0700 2006", tweets[j].CreatedAt)
+			timeI, _ := time.Parse(twitterTimeLayout, tweets[i].CreatedAt)
+			timeJ, _ := time.Parse(twitterTimeLayout, tweets[j].CreatedAt)
 			return timeI.Before(timeJ) // oldest first
 		})
 		fmt.Println("Sorted tweets by date (oldest first)")
 	case "newest":
 		sort.Slice(tweets, func(i, j int) bool {
-			timeI, _ := time.Parse("Mon Jan 02 15:04:05 -0700 2006", tweets[i].CreatedAt)
-			timeJ, _ := time.Parse("Mon Jan 02 15:04:05 -0700 2006", tweets[j].CreatedAt)
+			timeI, _ := time.Parse(twitterTimeLayout, tweets[i].CreatedAt)
+			timeJ, _ := time.Parse(twitterTimeLayout, tweets[j].CreatedAt)
 			return timeI.After(timeJ) // newest first
 		})
 		fmt.Println("Sorted tweets by date (newest first)")
@@ -239,7 +230,7 @@ func runDelete(cmd *cobra.Command, args []string) {
 	fmt.Println("=========================")
 
 	for i, tweet := range tweets {
-		createdAt, _ := time.Parse("Mon Jan 02 15:04:05 -0700 2006", tweet.CreatedAt)
+		createdAt, _ := time.Parse(twitterTimeLayout, tweet.CreatedAt)
 		formattedDate := createdAt.Format("2006/01/02 15:04:05")
 
 		// Truncate text if it's too long
@@ -267,77 +258,62 @@ func runDelete(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	// Setup OAuth 1.0a authentication
-	config := oauth1.NewConfig(consumerKey, consumerSecret)
-	token := oauth1.NewToken(accessToken, accessTokenSecret)
-	httpClient := config.Client(oauth1.NoContext, token)
-
-	// Execute deletion
-	fmt.Println("Checking tweets status and executing deletion...")
-	success := 0
-	failures := 0
-	alreadyDeleted := 0
-
-	// Twitter API v2 only allows 50 requests per 15 minutes for free tier
-	const rateLimit = 50
-	const rateLimitWindow = 15 * time.Minute
-
-	for i, tweet := range tweets {
-		// Check if we need to pause for rate limiting
-		if i > 0 && i%rateLimit == 0 {
-			fmt.Printf("Rate limit reached. Waiting for %s before continuing...\n", rateLimitWindow)
-			time.Sleep(rateLimitWindow)
+	// Open the state store, if requested, and record each targeted tweet as
+	// pending so the job can be resumed if it's interrupted
+	var st *store.Store
+	if statePath != "" {
+		var err error
+		st, err = store.Open(statePath)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
 		}
+		defer st.Close()
 
-		// Check if tweet still exists
-		if !checkTweetExists(httpClient, tweet.ID) {
-			fmt.Printf("Tweet already deleted: ID %s\n", tweet.ID)
-			alreadyDeleted++
-			continue
+		for _, tweet := range tweets {
+			if _, ok, err := st.Get(tweet.ID); err == nil && ok {
+				continue
+			}
+			recordStatus(st, tweet.ID, store.StatusPending, "")
 		}
+	}
 
-		// Create delete request using OAuth 1.0a
-		url := fmt.Sprintf("https://api.twitter.com/2/tweets/%s", tweet.ID)
-		req, err := http.NewRequest("DELETE", url, nil)
-		if err != nil {
-			fmt.Printf("Error creating request for tweet ID %s: %v\n", tweet.ID, err)
-			failures++
-			continue
-		}
+	// Execute deletion across a pool of workers, skipping anything the state
+	// store already marked as deleted from a previous run
+	fmt.Printf("Checking tweets status and executing deletion with %d worker(s)...\n", concurrency)
+	success := 0
+	failures := 0
+	alreadyDeleted := 0
+	skipped := 0
 
-		// Send request with OAuth 1.0a authentication
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			fmt.Printf("Error deleting tweet ID %s: %v\n", tweet.ID, err)
-			failures++
+	var tweetIDs []string
+	for _, tweet := range tweets {
+		if alreadyDeletedInStore(st, tweet.ID) {
+			fmt.Printf("Skipping tweet %s: already marked deleted in state store\n", tweet.ID)
+			skipped++
 			continue
 		}
+		tweetIDs = append(tweetIDs, tweet.ID)
+	}
 
-		// Parse response
-		defer resp.Body.Close()
-		body, err := ioutil.ReadAll(resp.Body)
-
-		if resp.StatusCode != 200 {
-			fmt.Printf("Failed to delete tweet ID %s: HTTP %d - %s\n", tweet.ID, resp.StatusCode, string(body))
-
-			// If we hit a 429 (Too Many Requests), wait longer
-			if resp.StatusCode == 429 {
-				fmt.Println("Rate limit exceeded. Waiting for 15 minutes...")
-				time.Sleep(15 * time.Minute)
-			}
+	runDeletionWorkers(httpClient, tweetIDs, concurrency, maxRetries, attemptDeleteTweet, func(result deletionResult) {
+		recordStatus(st, result.ID, result.Status, result.Reason)
 
-			failures++
-		} else {
+		switch result.Status {
+		case store.StatusAlreadyGone:
+			fmt.Printf("Tweet already deleted: ID %s\n", result.ID)
+			alreadyDeleted++
+		case store.StatusDeleted:
 			success++
-			fmt.Printf("Deleted: %d/%d - ID: %s\n", success, len(tweets), tweet.ID)
+			fmt.Printf("Deleted: %d/%d - ID: %s\n", success, len(tweetIDs), result.ID)
+		case store.StatusFailed:
+			fmt.Printf("Failed to delete tweet ID %s: %s\n", result.ID, result.Reason)
+			failures++
 		}
+	})
 
-		// Wait a bit between requests to be nice to the API
-		time.Sleep(200 * time.Millisecond)
-	}
-
-	fmt.Printf("\nCompleted: Successfully deleted %d/%d tweets. Failed: %d, Already deleted: %d\n",
-		success, len(tweets), failures, alreadyDeleted)
+	fmt.Printf("\nCompleted: Successfully deleted %d/%d tweets. Failed: %d, Already deleted: %d, Skipped: %d\n",
+		success, len(tweetIDs), failures, alreadyDeleted, skipped)
 
 	if failures > 0 {
 		fmt.Println("\nNote: Some tweets may have failed to delete because:")
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bmf-san/go-tweet-cleaner/internal/archive"
+	"github.com/bmf-san/go-tweet-cleaner/internal/store"
+)
+
+var (
+	likeArchivePath   string
+	likeDryRun        bool
+	likeConcurrency   int
+	likeMaxRetries    int
+	likeAllowlistPath string
+)
+
+var deleteLikesCmd = &cobra.Command{
+	Use:   "delete-likes",
+	Short: "Unlike tweets",
+	Long:  `Unlikes tweets based on IDs extracted from the Twitter archive's data/like.js file.`,
+	Run:   runDeleteLikes,
+}
+
+func init() {
+	rootCmd.AddCommand(deleteLikesCmd)
+	deleteLikesCmd.Flags().StringVar(&likeArchivePath, "archive", "", "Path to a Twitter archive directory or the downloaded .zip")
+	deleteLikesCmd.Flags().BoolVar(&likeDryRun, "dry-run", false, "Only show likes that would be removed without actually removing them")
+	deleteLikesCmd.Flags().IntVar(&likeConcurrency, "concurrency", 1, "Number of workers to run in parallel")
+	deleteLikesCmd.Flags().IntVar(&likeMaxRetries, "max-retries", 3, "Maximum number of retries for a transient failure (429 or 5xx)")
+	deleteLikesCmd.Flags().StringVar(&likeAllowlistPath, "allowlist", "", "Path to a file of tweet IDs (one per line) to never unlike")
+	deleteLikesCmd.MarkFlagRequired("archive")
+}
+
+func runDeleteLikes(cmd *cobra.Command, args []string) {
+	httpClient, err := newAuthenticatedClient()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	likes, err := archive.LoadLikes(likeArchivePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("Extracted %d liked tweets from the archive\n", len(likes))
+
+	tweetIDs := make([]string, len(likes))
+	for i, like := range likes {
+		tweetIDs[i] = like.TweetID
+	}
+
+	runEntityAction(httpClient, tweetIDs, likeAllowlistPath, likeDryRun, likeConcurrency, likeMaxRetries, entityActionLabels{
+		Noun:           "likes",
+		ConfirmNoun:    "tweets",
+		ConfirmVerb:    "Unlike",
+		DryRunSuffix:   "likes will be removed",
+		IDLabel:        "ID",
+		PastTense:      "Unliked",
+		AlreadyPrefix:  "Already unliked",
+		FailVerbPhrase: "unlike tweet",
+		SummaryVerb:    "unliked",
+	}, unlikeAction)
+}
+
+// unlikeAction returns a deleteAction that unlikes a tweet on behalf of userID.
+func unlikeAction(userID string) deleteAction {
+	return func(httpClient *http.Client, tweetID string) (store.Status, string, *http.Response) {
+		url := fmt.Sprintf("https://api.twitter.com/2/users/%s/likes/%s", userID, tweetID)
+		return doDeleteRequest(httpClient, url)
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bmf-san/go-tweet-cleaner/internal/archive"
+	"github.com/bmf-san/go-tweet-cleaner/internal/store"
+)
+
+var (
+	retweetArchivePath   string
+	retweetDryRun        bool
+	retweetConcurrency   int
+	retweetMaxRetries    int
+	retweetAllowlistPath string
+)
+
+var deleteRetweetsCmd = &cobra.Command{
+	Use:   "delete-retweets",
+	Short: "Undo retweets",
+	Long:  `Undoes retweets found among the archive's tweets.`,
+	Run:   runDeleteRetweets,
+}
+
+func init() {
+	rootCmd.AddCommand(deleteRetweetsCmd)
+	deleteRetweetsCmd.Flags().StringVar(&retweetArchivePath, "archive", "", "Path to a Twitter archive directory or the downloaded .zip")
+	deleteRetweetsCmd.Flags().BoolVar(&retweetDryRun, "dry-run", false, "Only show retweets that would be undone without actually undoing them")
+	deleteRetweetsCmd.Flags().IntVar(&retweetConcurrency, "concurrency", 1, "Number of workers to run in parallel")
+	deleteRetweetsCmd.Flags().IntVar(&retweetMaxRetries, "max-retries", 3, "Maximum number of retries for a transient failure (429 or 5xx)")
+	deleteRetweetsCmd.Flags().StringVar(&retweetAllowlistPath, "allowlist", "", "Path to a file of tweet IDs (one per line) to never undo")
+	deleteRetweetsCmd.MarkFlagRequired("archive")
+}
+
+func runDeleteRetweets(cmd *cobra.Command, args []string) {
+	httpClient, err := newAuthenticatedClient()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	retweets, err := archive.LoadRetweets(retweetArchivePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("Extracted %d retweets from the archive\n", len(retweets))
+
+	sourceTweetIDs := make([]string, len(retweets))
+	unrecovered := 0
+	for i, rt := range retweets {
+		sourceTweetIDs[i] = rt.SourceTweetID
+		if !rt.SourceIDRecovered {
+			unrecovered++
+		}
+	}
+
+	if unrecovered > 0 {
+		fmt.Printf("\nWARNING: could not recover the original tweet ID for %d of these retweets;\n", unrecovered)
+		fmt.Println("WARNING: the API call for those will use the retweet's own ID, which Twitter")
+		fmt.Println("WARNING: will reject, so undoing them through this command WILL FAIL.")
+	}
+
+	runEntityAction(httpClient, sourceTweetIDs, retweetAllowlistPath, retweetDryRun, retweetConcurrency, retweetMaxRetries, entityActionLabels{
+		Noun:           "retweets",
+		ConfirmNoun:    "retweets",
+		ConfirmVerb:    "Undo",
+		DryRunSuffix:   "retweets will be undone",
+		IDLabel:        "source tweet ID",
+		PastTense:      "Undone",
+		AlreadyPrefix:  "Already undone",
+		FailVerbPhrase: "undo retweet of source tweet",
+		SummaryVerb:    "undid",
+	}, unretweetAction)
+}
+
+// unretweetAction returns a deleteAction that undoes a retweet of
+// sourceTweetID on behalf of userID.
+func unretweetAction(userID string) deleteAction {
+	return func(httpClient *http.Client, sourceTweetID string) (store.Status, string, *http.Response) {
+		url := fmt.Sprintf("https://api.twitter.com/2/users/%s/retweets/%s", userID, sourceTweetID)
+		return doDeleteRequest(httpClient, url)
+	}
+}
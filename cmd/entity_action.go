@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/bmf-san/go-tweet-cleaner/internal/store"
+)
+
+// entityActionLabels names the text used by runEntityAction's prompts,
+// per-result lines, and summary, so the same load -> filter-by-allowlist ->
+// confirm -> run-workers -> summarize shape can be reused by delete-likes,
+// delete-retweets, and unfollow instead of being copy-pasted per entity type.
+type entityActionLabels struct {
+	// Noun is the plural entity name used in the filtering-stage messages,
+	// e.g. "likes", "retweets", "accounts".
+	Noun string
+	// ConfirmNoun is the plural noun used in the confirmation prompt, e.g.
+	// "tweets" for delete-likes (it confirms the tweets being unliked, not
+	// the "likes" themselves).
+	ConfirmNoun string
+	// ConfirmVerb is the capitalized verb used in the confirmation prompt,
+	// e.g. "Unlike", "Undo", "Unfollow".
+	ConfirmVerb string
+	// DryRunSuffix completes "Dry run mode - no " in the dry-run message,
+	// e.g. "likes will be removed".
+	DryRunSuffix string
+	// IDLabel names the ID printed alongside each result, e.g. "ID",
+	// "source tweet ID", "account ID".
+	IDLabel string
+	// PastTense is the capitalized past-tense verb used in the per-result
+	// success line, e.g. "Unliked", "Undone", "Unfollowed".
+	PastTense string
+	// AlreadyPrefix is the full "Already ..." phrase used when an item is
+	// already in the target state, e.g. "Already unliked",
+	// "Already undone", "Already not following".
+	AlreadyPrefix string
+	// FailVerbPhrase completes "Failed to " in the per-failure line, e.g.
+	// "unlike tweet", "undo retweet of source tweet", "unfollow account".
+	FailVerbPhrase string
+	// SummaryVerb is the lowercase verb used in the completion summary,
+	// e.g. "unliked", "undid", "unfollowed".
+	SummaryVerb string
+}
+
+// runEntityAction implements the load -> filter-by-allowlist -> confirm ->
+// run-workers -> summarize shape shared by delete-likes, delete-retweets,
+// and unfollow. ids is the full set of IDs extracted from the archive,
+// before allowlist filtering; actionFor builds the deleteAction to run once
+// the authenticated user's ID is known.
+func runEntityAction(httpClient *http.Client, ids []string, allowlistPath string, dryRun bool, concurrency, maxRetries int, labels entityActionLabels, actionFor func(userID string) deleteAction) {
+	var allowlist map[string]bool
+	if allowlistPath != "" {
+		var err error
+		allowlist, err = loadAllowlist(allowlistPath)
+		if err != nil {
+			fmt.Printf("Error: failed to read --allowlist %q: %v\n", allowlistPath, err)
+			return
+		}
+	}
+
+	var targetIDs []string
+	for _, id := range ids {
+		if allowlist[id] {
+			continue
+		}
+		targetIDs = append(targetIDs, id)
+	}
+	fmt.Printf("%d %s targeted after filtering\n", len(targetIDs), labels.Noun)
+
+	if len(targetIDs) == 0 {
+		fmt.Printf("No %s left after filtering\n", labels.Noun)
+		return
+	}
+
+	if !dryRun {
+		fmt.Printf("\n%s the above %d %s? [y/N]: ", labels.ConfirmVerb, len(targetIDs), labels.ConfirmNoun)
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+
+		if response != "y" {
+			fmt.Println("Cancelled.")
+			return
+		}
+	} else {
+		fmt.Printf("\nDry run mode - no %s\n", labels.DryRunSuffix)
+		return
+	}
+
+	userID, err := getAuthenticatedUserID(httpClient)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	success := 0
+	failures := 0
+	alreadyGone := 0
+
+	runDeletionWorkers(httpClient, targetIDs, concurrency, maxRetries, actionFor(userID), func(result deletionResult) {
+		switch result.Status {
+		case store.StatusAlreadyGone:
+			fmt.Printf("%s: %s %s\n", labels.AlreadyPrefix, labels.IDLabel, result.ID)
+			alreadyGone++
+		case store.StatusDeleted:
+			success++
+			fmt.Printf("%s: %d/%d - %s: %s\n", labels.PastTense, success, len(targetIDs), labels.IDLabel, result.ID)
+		case store.StatusFailed:
+			fmt.Printf("Failed to %s %s %s: %s\n", labels.FailVerbPhrase, labels.IDLabel, result.ID, result.Reason)
+			failures++
+		}
+	})
+
+	fmt.Printf("\nCompleted: Successfully %s %d/%d %s. Failed: %d, %s: %d\n",
+		labels.SummaryVerb, success, len(targetIDs), labels.ConfirmNoun, failures, labels.AlreadyPrefix, alreadyGone)
+}
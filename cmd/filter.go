@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterOptions configures which archive tweets filterTweets keeps versus
+// excludes from deletion.
+type FilterOptions struct {
+	KeepDays        int
+	Before          time.Time
+	After           time.Time
+	Match           *regexp.Regexp
+	NotMatch        *regexp.Regexp
+	MinLikes        int
+	MinRetweets     int
+	ExcludeReplies  bool
+	ExcludeRetweets bool
+	Allowlist       map[string]bool
+	Now             time.Time
+}
+
+// FilterSummary reports how many tweets each individual filter excluded.
+// A tweet is only counted against the first filter that excludes it, so the
+// counts sum to Total-Remaining.
+type FilterSummary struct {
+	Total           int
+	Allowlist       int
+	KeepDays        int
+	DateRange       int
+	Match           int
+	NotMatch        int
+	MinLikes        int
+	MinRetweets     int
+	ExcludeReplies  int
+	ExcludeRetweets int
+	Remaining       int
+}
+
+// buildFilterOptions translates the delete command's filter flags into a
+// FilterOptions value, returning an error if a flag value can't be parsed.
+func buildFilterOptions() (FilterOptions, error) {
+	opts := FilterOptions{
+		KeepDays:        keepDays,
+		MinLikes:        minLikes,
+		MinRetweets:     minRetweets,
+		ExcludeReplies:  excludeReplies,
+		ExcludeRetweets: excludeRetweets,
+		Now:             time.Now(),
+	}
+
+	if beforeDate != "" {
+		t, err := time.Parse(archiveDateLayout, beforeDate)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --before date %q: %w", beforeDate, err)
+		}
+		opts.Before = t
+	}
+
+	if afterDate != "" {
+		t, err := time.Parse(archiveDateLayout, afterDate)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --after date %q: %w", afterDate, err)
+		}
+		opts.After = t
+	}
+
+	if matchPattern != "" {
+		re, err := regexp.Compile(matchPattern)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --match pattern %q: %w", matchPattern, err)
+		}
+		opts.Match = re
+	}
+
+	if notMatchPattern != "" {
+		re, err := regexp.Compile(notMatchPattern)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --not-match pattern %q: %w", notMatchPattern, err)
+		}
+		opts.NotMatch = re
+	}
+
+	if allowlistPath != "" {
+		allowlist, err := loadAllowlist(allowlistPath)
+		if err != nil {
+			return opts, fmt.Errorf("failed to read --allowlist %q: %w", allowlistPath, err)
+		}
+		opts.Allowlist = allowlist
+	}
+
+	return opts, nil
+}
+
+// loadAllowlist reads a file of tweet IDs, one per line, and returns the set
+// of IDs it contains. Blank lines are ignored.
+func loadAllowlist(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	allowlist := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		id := strings.TrimSpace(scanner.Text())
+		if id == "" {
+			continue
+		}
+		allowlist[id] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return allowlist, nil
+}
+
+// filterTweets applies opts to tweets and returns the tweets that should
+// still be considered for deletion, along with a summary of how many
+// tweets each predicate excluded.
+func filterTweets(tweets []TwitterArchiveTweet, opts FilterOptions) ([]TwitterArchiveTweet, FilterSummary) {
+	summary := FilterSummary{Total: len(tweets)}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	kept := make([]TwitterArchiveTweet, 0, len(tweets))
+	for _, tweet := range tweets {
+		switch {
+		case isAllowlisted(tweet, opts.Allowlist):
+			summary.Allowlist++
+		case isWithinKeepDays(tweet, opts.KeepDays, now):
+			summary.KeepDays++
+		case isOutsideDateRange(tweet, opts.Before, opts.After):
+			summary.DateRange++
+		case !matchesPattern(tweet, opts.Match):
+			summary.Match++
+		case opts.NotMatch != nil && opts.NotMatch.MatchString(tweet.Text):
+			summary.NotMatch++
+		case isBelowMinLikes(tweet, opts.MinLikes):
+			summary.MinLikes++
+		case isBelowMinRetweets(tweet, opts.MinRetweets):
+			summary.MinRetweets++
+		case opts.ExcludeReplies && isReply(tweet):
+			summary.ExcludeReplies++
+		case opts.ExcludeRetweets && isRetweet(tweet):
+			summary.ExcludeRetweets++
+		default:
+			kept = append(kept, tweet)
+		}
+	}
+
+	summary.Remaining = len(kept)
+	return kept, summary
+}
+
+// isAllowlisted reports whether tweet's ID is in allowlist. A nil or empty
+// allowlist never matches.
+func isAllowlisted(tweet TwitterArchiveTweet, allowlist map[string]bool) bool {
+	return allowlist[tweet.ID]
+}
+
+// isWithinKeepDays reports whether tweet is younger than keepDays days,
+// relative to now. A non-positive keepDays disables the check.
+func isWithinKeepDays(tweet TwitterArchiveTweet, keepDays int, now time.Time) bool {
+	if keepDays <= 0 {
+		return false
+	}
+	createdAt, err := time.Parse(twitterTimeLayout, tweet.CreatedAt)
+	if err != nil {
+		return false
+	}
+	return now.Sub(createdAt) < time.Duration(keepDays)*24*time.Hour
+}
+
+// isOutsideDateRange reports whether tweet falls outside the (after, before)
+// window; both bounds are exclusive. Zero values for before/after disable
+// the respective bound.
+func isOutsideDateRange(tweet TwitterArchiveTweet, before, after time.Time) bool {
+	if before.IsZero() && after.IsZero() {
+		return false
+	}
+	createdAt, err := time.Parse(twitterTimeLayout, tweet.CreatedAt)
+	if err != nil {
+		return false
+	}
+	if !before.IsZero() && !createdAt.Before(before) {
+		return true
+	}
+	if !after.IsZero() && !createdAt.After(after) {
+		return true
+	}
+	return false
+}
+
+// matchesPattern reports whether tweet's text matches re. A nil re always
+// matches, so callers that only care about --not-match can still use it.
+func matchesPattern(tweet TwitterArchiveTweet, re *regexp.Regexp) bool {
+	if re == nil {
+		return true
+	}
+	return re.MatchString(tweet.Text)
+}
+
+// isBelowMinLikes reports whether tweet has fewer than minLikes favorites.
+// A non-positive minLikes disables the check.
+func isBelowMinLikes(tweet TwitterArchiveTweet, minLikes int) bool {
+	if minLikes <= 0 {
+		return false
+	}
+	count, _ := strconv.Atoi(tweet.FavoriteCount)
+	return count < minLikes
+}
+
+// isBelowMinRetweets reports whether tweet has fewer than minRetweets
+// retweets. A non-positive minRetweets disables the check.
+func isBelowMinRetweets(tweet TwitterArchiveTweet, minRetweets int) bool {
+	if minRetweets <= 0 {
+		return false
+	}
+	count, _ := strconv.Atoi(tweet.RetweetCount)
+	return count < minRetweets
+}
+
+// isReply reports whether tweet is a reply to another tweet.
+func isReply(tweet TwitterArchiveTweet) bool {
+	return tweet.InReplyToStatusID != ""
+}
+
+// isRetweet reports whether tweet is a retweet.
+func isRetweet(tweet TwitterArchiveTweet) bool {
+	return tweet.Retweeted || strings.HasPrefix(tweet.Text, "RT @")
+}
+
+// printFilterSummary prints how many tweets each filter excluded.
+func printFilterSummary(summary FilterSummary) {
+	fmt.Println("\nFilter summary:")
+	fmt.Printf("  Total tweets:          %d\n", summary.Total)
+	if summary.Allowlist > 0 {
+		fmt.Printf("  Excluded (allowlist):  %d\n", summary.Allowlist)
+	}
+	if summary.KeepDays > 0 {
+		fmt.Printf("  Excluded (keep-days):  %d\n", summary.KeepDays)
+	}
+	if summary.DateRange > 0 {
+		fmt.Printf("  Excluded (date range): %d\n", summary.DateRange)
+	}
+	if summary.Match > 0 {
+		fmt.Printf("  Excluded (no match):   %d\n", summary.Match)
+	}
+	if summary.NotMatch > 0 {
+		fmt.Printf("  Excluded (not-match):  %d\n", summary.NotMatch)
+	}
+	if summary.MinLikes > 0 {
+		fmt.Printf("  Excluded (min-likes):  %d\n", summary.MinLikes)
+	}
+	if summary.MinRetweets > 0 {
+		fmt.Printf("  Excluded (min-retweets): %d\n", summary.MinRetweets)
+	}
+	if summary.ExcludeReplies > 0 {
+		fmt.Printf("  Excluded (replies):    %d\n", summary.ExcludeReplies)
+	}
+	if summary.ExcludeRetweets > 0 {
+		fmt.Printf("  Excluded (retweets):   %d\n", summary.ExcludeRetweets)
+	}
+	fmt.Printf("  Remaining for deletion: %d\n", summary.Remaining)
+}
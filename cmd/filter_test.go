@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func mustParseArchiveTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	ts, err := time.Parse(twitterTimeLayout, s)
+	if err != nil {
+		t.Fatalf("failed to parse test time %q: %v", s, err)
+	}
+	return ts
+}
+
+func TestIsAllowlisted(t *testing.T) {
+	tweet := TwitterArchiveTweet{ID: "123"}
+
+	if isAllowlisted(tweet, nil) {
+		t.Error("expected nil allowlist to never match")
+	}
+	if isAllowlisted(tweet, map[string]bool{"456": true}) {
+		t.Error("expected ID not in allowlist to not match")
+	}
+	if !isAllowlisted(tweet, map[string]bool{"123": true}) {
+		t.Error("expected ID in allowlist to match")
+	}
+}
+
+func TestIsWithinKeepDays(t *testing.T) {
+	now := mustParseArchiveTime(t, "Wed Jul 20 12:00:00 +0000 2026")
+
+	cases := []struct {
+		name      string
+		createdAt string
+		keepDays  int
+		want      bool
+	}{
+		{"disabled", "Wed Jul 20 00:00:00 +0000 2026", 0, false},
+		{"recent tweet within window", "Wed Jul 20 00:00:00 +0000 2026", 7, true},
+		{"old tweet outside window", "Wed Jun 01 00:00:00 +0000 2026", 7, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tweet := TwitterArchiveTweet{CreatedAt: tc.createdAt}
+			if got := isWithinKeepDays(tweet, tc.keepDays, now); got != tc.want {
+				t.Errorf("isWithinKeepDays() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsOutsideDateRange(t *testing.T) {
+	before := mustParseArchiveTime(t, "Wed Jul 01 00:00:00 +0000 2026")
+	after := mustParseArchiveTime(t, "Tue Jun 01 00:00:00 +0000 2026")
+
+	cases := []struct {
+		name      string
+		createdAt string
+		want      bool
+	}{
+		{"within range", "Sat Jun 15 00:00:00 +0000 2026", false},
+		{"before the after bound", "Sun May 01 00:00:00 +0000 2026", true},
+		{"after the before bound", "Wed Aug 01 00:00:00 +0000 2026", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tweet := TwitterArchiveTweet{CreatedAt: tc.createdAt}
+			if got := isOutsideDateRange(tweet, before, after); got != tc.want {
+				t.Errorf("isOutsideDateRange() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchesPattern(t *testing.T) {
+	tweet := TwitterArchiveTweet{Text: "hello world"}
+
+	if !matchesPattern(tweet, nil) {
+		t.Error("expected nil pattern to always match")
+	}
+	if !matchesPattern(tweet, regexp.MustCompile(`^hello`)) {
+		t.Error("expected matching pattern to match")
+	}
+	if matchesPattern(tweet, regexp.MustCompile(`^goodbye`)) {
+		t.Error("expected non-matching pattern to not match")
+	}
+}
+
+func TestIsBelowMinLikes(t *testing.T) {
+	tweet := TwitterArchiveTweet{FavoriteCount: "5"}
+
+	if isBelowMinLikes(tweet, 0) {
+		t.Error("expected disabled threshold to not exclude")
+	}
+	if isBelowMinLikes(tweet, 5) {
+		t.Error("expected tweet meeting threshold to not exclude")
+	}
+	if !isBelowMinLikes(tweet, 10) {
+		t.Error("expected tweet below threshold to exclude")
+	}
+}
+
+func TestIsBelowMinRetweets(t *testing.T) {
+	tweet := TwitterArchiveTweet{RetweetCount: "3"}
+
+	if isBelowMinRetweets(tweet, 0) {
+		t.Error("expected disabled threshold to not exclude")
+	}
+	if !isBelowMinRetweets(tweet, 4) {
+		t.Error("expected tweet below threshold to exclude")
+	}
+}
+
+func TestIsReply(t *testing.T) {
+	if isReply(TwitterArchiveTweet{}) {
+		t.Error("expected tweet without in_reply_to_status_id_str to not be a reply")
+	}
+	if !isReply(TwitterArchiveTweet{InReplyToStatusID: "42"}) {
+		t.Error("expected tweet with in_reply_to_status_id_str to be a reply")
+	}
+}
+
+func TestIsRetweet(t *testing.T) {
+	if isRetweet(TwitterArchiveTweet{}) {
+		t.Error("expected plain tweet to not be a retweet")
+	}
+	if !isRetweet(TwitterArchiveTweet{Retweeted: true}) {
+		t.Error("expected retweeted flag to count as a retweet")
+	}
+	if !isRetweet(TwitterArchiveTweet{Text: "RT @someone: hi"}) {
+		t.Error("expected RT-prefixed text to count as a retweet")
+	}
+}
+
+func TestFilterTweets(t *testing.T) {
+	tweets := []TwitterArchiveTweet{
+		{ID: "1", Text: "kept", CreatedAt: "Sat Jun 15 00:00:00 +0000 2026", FavoriteCount: "10", RetweetCount: "2"},
+		{ID: "2", Text: "allowlisted", CreatedAt: "Sat Jun 15 00:00:00 +0000 2026"},
+		{ID: "3", Text: "RT @someone: spam", CreatedAt: "Sat Jun 15 00:00:00 +0000 2026", FavoriteCount: "10"},
+		{ID: "4", Text: "a reply", CreatedAt: "Sat Jun 15 00:00:00 +0000 2026", FavoriteCount: "10", InReplyToStatusID: "99"},
+		{ID: "5", Text: "low engagement", CreatedAt: "Sat Jun 15 00:00:00 +0000 2026", FavoriteCount: "0"},
+	}
+
+	opts := FilterOptions{
+		MinLikes:        1,
+		ExcludeReplies:  true,
+		ExcludeRetweets: true,
+		Allowlist:       map[string]bool{"2": true},
+		Now:             mustParseArchiveTime(t, "Sun Jun 16 00:00:00 +0000 2026"),
+	}
+
+	kept, summary := filterTweets(tweets, opts)
+
+	if len(kept) != 1 || kept[0].ID != "1" {
+		t.Errorf("expected only tweet 1 to survive filtering, got %+v", kept)
+	}
+	if summary.Total != 5 {
+		t.Errorf("summary.Total = %d, want 5", summary.Total)
+	}
+	if summary.Allowlist != 1 {
+		t.Errorf("summary.Allowlist = %d, want 1", summary.Allowlist)
+	}
+	if summary.ExcludeRetweets != 1 {
+		t.Errorf("summary.ExcludeRetweets = %d, want 1", summary.ExcludeRetweets)
+	}
+	if summary.ExcludeReplies != 1 {
+		t.Errorf("summary.ExcludeReplies = %d, want 1", summary.ExcludeReplies)
+	}
+	if summary.MinLikes != 1 {
+		t.Errorf("summary.MinLikes = %d, want 1", summary.MinLikes)
+	}
+	if summary.Remaining != 1 {
+		t.Errorf("summary.Remaining = %d, want 1", summary.Remaining)
+	}
+}
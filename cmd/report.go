@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bmf-san/go-tweet-cleaner/internal/store"
+)
+
+var (
+	reportStatePath string
+	reportFormat    string
+	reportOutput    string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Print or export the current job status",
+	Long:  `Reads a --state database and prints (or exports as CSV/JSON) the deletion status recorded for every tweet.`,
+	Run:   runReport,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.Flags().StringVar(&reportStatePath, "state", "", "Path to the SQLite database created by a previous delete run")
+	reportCmd.Flags().StringVar(&reportFormat, "format", "json", "Output format: 'json' or 'csv'")
+	reportCmd.Flags().StringVar(&reportOutput, "output", "", "File to write the report to (defaults to stdout)")
+	reportCmd.MarkFlagRequired("state")
+}
+
+func runReport(cmd *cobra.Command, args []string) {
+	st, err := store.Open(reportStatePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	defer st.Close()
+
+	records, err := st.All()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	out := os.Stdout
+	if reportOutput != "" {
+		f, err := os.Create(reportOutput)
+		if err != nil {
+			fmt.Printf("Error: failed to create %s: %v\n", reportOutput, err)
+			return
+		}
+		defer f.Close()
+		out = f
+	}
+
+	switch reportFormat {
+	case "json":
+		err = writeReportJSON(out, records)
+	case "csv":
+		err = writeReportCSV(out, records)
+	default:
+		fmt.Printf("Error: unknown --format %q, expected 'json' or 'csv'\n", reportFormat)
+		return
+	}
+
+	if err != nil {
+		fmt.Printf("Error: failed to write report: %v\n", err)
+	}
+}
+
+func writeReportJSON(out *os.File, records []store.Record) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+func writeReportCSV(out *os.File, records []store.Record) error {
+	writer := csv.NewWriter(out)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"id", "status", "reason", "updated_at"}); err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		if err := writer.Write([]string{rec.ID, string(rec.Status), rec.Reason, rec.UpdatedAt}); err != nil {
+			return err
+		}
+	}
+
+	return writer.Error()
+}
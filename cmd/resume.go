@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bmf-san/go-tweet-cleaner/internal/store"
+)
+
+var (
+	resumeStatePath   string
+	resumeConcurrency int
+	resumeMaxRetries  int
+)
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume an interrupted delete job",
+	Long:  `Continues a delete job from a --state database, retrying every tweet that isn't yet deleted or already-gone, without re-parsing the archive.`,
+	Run:   runResume,
+}
+
+func init() {
+	rootCmd.AddCommand(resumeCmd)
+	resumeCmd.Flags().StringVar(&resumeStatePath, "state", "", "Path to the SQLite database created by a previous delete run")
+	resumeCmd.MarkFlagRequired("state")
+
+	resumeCmd.Flags().IntVar(&resumeConcurrency, "concurrency", 1, "Number of deletion workers to run in parallel")
+	resumeCmd.Flags().IntVar(&resumeMaxRetries, "max-retries", 3, "Maximum number of retries for a transient failure (429 or 5xx)")
+}
+
+func runResume(cmd *cobra.Command, args []string) {
+	httpClient, err := newAuthenticatedClient()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	st, err := store.Open(resumeStatePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	defer st.Close()
+
+	records, err := st.Unresolved()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	if len(records) == 0 {
+		fmt.Println("Nothing to resume: no pending or failed tweets in the state store")
+		return
+	}
+
+	fmt.Printf("Resuming job: %d tweets left to process with %d worker(s)\n", len(records), resumeConcurrency)
+
+	tweetIDs := make([]string, len(records))
+	for i, rec := range records {
+		tweetIDs[i] = rec.ID
+	}
+
+	success := 0
+	failures := 0
+	alreadyDeleted := 0
+
+	runDeletionWorkers(httpClient, tweetIDs, resumeConcurrency, resumeMaxRetries, attemptDeleteTweet, func(result deletionResult) {
+		recordStatus(st, result.ID, result.Status, result.Reason)
+
+		switch result.Status {
+		case store.StatusAlreadyGone:
+			fmt.Printf("Tweet already deleted: ID %s\n", result.ID)
+			alreadyDeleted++
+		case store.StatusDeleted:
+			success++
+			fmt.Printf("Deleted: %d/%d - ID: %s\n", success, len(tweetIDs), result.ID)
+		case store.StatusFailed:
+			fmt.Printf("Failed to delete tweet ID %s: %s\n", result.ID, result.Reason)
+			failures++
+		}
+	})
+
+	fmt.Printf("\nResume completed: Successfully deleted %d/%d tweets. Failed: %d, Already deleted: %d\n",
+		success, len(tweetIDs), failures, alreadyDeleted)
+}
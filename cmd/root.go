@@ -24,6 +24,13 @@ var rootCmd = &cobra.Command{
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
+	// The four OAuth1 flags are persistent so they can be passed to any
+	// command, but they're only actually needed by commands that call
+	// newAuthenticatedClient (delete, delete-likes, delete-retweets,
+	// unfollow, resume) - report, for instance, only reads a local SQLite
+	// file. So they're left optional here, and newAuthenticatedClient is
+	// what enforces that either a saved `auth login` token or all four
+	// flags are present before it builds a client.
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -36,9 +43,4 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&accessToken, "access-token", "", "Twitter Access Token")
 	rootCmd.PersistentFlags().StringVar(&accessTokenSecret, "access-token-secret", "", "Twitter Access Token Secret")
 	rootCmd.PersistentFlags().IntVar(&limit, "limit", 100, "Number of tweets to process at once (maximum 100)")
-
-	rootCmd.MarkPersistentFlagRequired("consumer-key")
-	rootCmd.MarkPersistentFlagRequired("consumer-secret")
-	rootCmd.MarkPersistentFlagRequired("access-token")
-	rootCmd.MarkPersistentFlagRequired("access-token-secret")
 }
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bmf-san/go-tweet-cleaner/internal/archive"
+	"github.com/bmf-san/go-tweet-cleaner/internal/store"
+)
+
+var (
+	unfollowArchivePath   string
+	unfollowDryRun        bool
+	unfollowConcurrency   int
+	unfollowMaxRetries    int
+	unfollowAllowlistPath string
+)
+
+var unfollowCmd = &cobra.Command{
+	Use:   "unfollow",
+	Short: "Unfollow accounts",
+	Long:  `Unfollows accounts based on IDs extracted from the Twitter archive's data/following.js file.`,
+	Run:   runUnfollow,
+}
+
+func init() {
+	rootCmd.AddCommand(unfollowCmd)
+	unfollowCmd.Flags().StringVar(&unfollowArchivePath, "archive", "", "Path to a Twitter archive directory or the downloaded .zip")
+	unfollowCmd.Flags().BoolVar(&unfollowDryRun, "dry-run", false, "Only show accounts that would be unfollowed without actually unfollowing them")
+	unfollowCmd.Flags().IntVar(&unfollowConcurrency, "concurrency", 1, "Number of workers to run in parallel")
+	unfollowCmd.Flags().IntVar(&unfollowMaxRetries, "max-retries", 3, "Maximum number of retries for a transient failure (429 or 5xx)")
+	unfollowCmd.Flags().StringVar(&unfollowAllowlistPath, "allowlist", "", "Path to a file of account IDs (one per line) to never unfollow")
+	unfollowCmd.MarkFlagRequired("archive")
+}
+
+func runUnfollow(cmd *cobra.Command, args []string) {
+	httpClient, err := newAuthenticatedClient()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	follows, err := archive.LoadFollowing(unfollowArchivePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Printf("Extracted %d followed accounts from the archive\n", len(follows))
+
+	accountIDs := make([]string, len(follows))
+	for i, follow := range follows {
+		accountIDs[i] = follow.AccountID
+	}
+
+	runEntityAction(httpClient, accountIDs, unfollowAllowlistPath, unfollowDryRun, unfollowConcurrency, unfollowMaxRetries, entityActionLabels{
+		Noun:           "accounts",
+		ConfirmNoun:    "accounts",
+		ConfirmVerb:    "Unfollow",
+		DryRunSuffix:   "accounts will be unfollowed",
+		IDLabel:        "account ID",
+		PastTense:      "Unfollowed",
+		AlreadyPrefix:  "Already not following",
+		FailVerbPhrase: "unfollow account",
+		SummaryVerb:    "unfollowed",
+	}, unfollowAction)
+}
+
+// unfollowAction returns a deleteAction that unfollows targetUserID on
+// behalf of userID.
+func unfollowAction(userID string) deleteAction {
+	return func(httpClient *http.Client, targetUserID string) (store.Status, string, *http.Response) {
+		url := fmt.Sprintf("https://api.twitter.com/2/users/%s/following/%s", userID, targetUserID)
+		return doDeleteRequest(httpClient, url)
+	}
+}
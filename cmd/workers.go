@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bmf-san/go-tweet-cleaner/internal/ratelimit"
+	"github.com/bmf-san/go-tweet-cleaner/internal/store"
+)
+
+// deletionJob is a single ID (tweet, like, retweet, or account) waiting to
+// be processed by a deleteAction.
+type deletionJob struct {
+	ID string
+}
+
+// deletionResult is the outcome of running a deleteAction against one ID.
+type deletionResult struct {
+	ID     string
+	Status store.Status
+	Reason string
+}
+
+// deleteAction makes a single, unretried attempt against id (a tweet, like,
+// retweet, or account ID), returning the resulting status, an optional
+// failure reason, and the raw HTTP response (nil when nothing was gone
+// through) so callers can inspect rate limit headers.
+type deleteAction func(httpClient *http.Client, id string) (store.Status, string, *http.Response)
+
+// runDeletionWorkers runs action against every ID in ids across concurrency
+// worker goroutines, sharing a single RateLimiter so every worker backs off
+// together when Twitter's per-endpoint limit is exhausted. Results are
+// delivered to onResult as each job finishes, in completion order.
+func runDeletionWorkers(httpClient *http.Client, ids []string, concurrency, maxRetries int, action deleteAction, onResult func(deletionResult)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	limiter := ratelimit.New()
+	jobs := make(chan deletionJob)
+	results := make(chan deletionResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				status, reason := runActionWithRetry(httpClient, limiter, action, job.ID, maxRetries)
+				results <- deletionResult{ID: job.ID, Status: status, Reason: reason}
+			}
+		}()
+	}
+
+	go func() {
+		for _, id := range ids {
+			jobs <- deletionJob{ID: id}
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		onResult(result)
+	}
+}
+
+// doDeleteRequest sends a DELETE request to url and translates the response
+// into a status: 200 is deleted, 404 is already gone, anything else failed.
+func doDeleteRequest(httpClient *http.Client, url string) (store.Status, string, *http.Response) {
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return store.StatusFailed, err.Error(), nil
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return store.StatusFailed, err.Error(), nil
+	}
+	defer resp.Body.Close()
+
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	switch resp.StatusCode {
+	case 200:
+		return store.StatusDeleted, "", resp
+	case 404:
+		return store.StatusAlreadyGone, "", resp
+	default:
+		return store.StatusFailed, fmt.Sprintf("HTTP %d - %s", resp.StatusCode, string(body)), resp
+	}
+}
+
+// attemptDeleteTweet makes a single, unretried attempt to delete tweetID.
+func attemptDeleteTweet(httpClient *http.Client, tweetID string) (store.Status, string, *http.Response) {
+	if !checkTweetExists(httpClient, tweetID) {
+		return store.StatusAlreadyGone, "", nil
+	}
+	return doDeleteRequest(httpClient, fmt.Sprintf("https://api.twitter.com/2/tweets/%s", tweetID))
+}
+
+// isTransientStatus reports whether a failed HTTP status is worth retrying.
+func isTransientStatus(statusCode int) bool {
+	return statusCode >= 500 && statusCode < 600
+}
+
+// runActionWithRetry runs action against id, honoring limiter and retrying
+// transient failures (429s and 5xx responses) with exponential backoff and
+// jitter, up to maxRetries times.
+func runActionWithRetry(httpClient *http.Client, limiter *ratelimit.RateLimiter, action deleteAction, id string, maxRetries int) (store.Status, string) {
+	var lastReason string
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		limiter.Wait()
+
+		status, reason, resp := action(httpClient, id)
+		limiter.Update(resp)
+		lastReason = reason
+
+		if rlErr := ratelimit.FromResponse(resp); rlErr != nil {
+			fmt.Printf("%s: %v\n", id, rlErr)
+			lastReason = rlErr.Error()
+			if attempt == maxRetries {
+				return store.StatusFailed, lastReason
+			}
+			wait := rlErr.RetryAfter
+			if wait <= 0 {
+				wait = ratelimit.Backoff(attempt, time.Second, time.Minute)
+			}
+			time.Sleep(wait)
+			continue
+		}
+
+		if status != store.StatusFailed {
+			return status, reason
+		}
+
+		if resp == nil || !isTransientStatus(resp.StatusCode) || attempt == maxRetries {
+			return status, reason
+		}
+
+		wait := ratelimit.Backoff(attempt, time.Second, 30*time.Second)
+		fmt.Printf("%s: transient failure (%s), retrying in %s\n", id, reason, wait)
+		time.Sleep(wait)
+	}
+
+	return store.StatusFailed, lastReason
+}
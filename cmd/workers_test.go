@@ -0,0 +1,20 @@
+package cmd
+
+import "testing"
+
+func TestIsTransientStatus(t *testing.T) {
+	cases := map[int]bool{
+		200: false,
+		404: false,
+		429: false,
+		500: true,
+		503: true,
+		600: false,
+	}
+
+	for status, want := range cases {
+		if got := isTransientStatus(status); got != want {
+			t.Errorf("isTransientStatus(%d) = %v, want %v", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+// Package archive parses Twitter's downloaded-archive `window.YTD.*` export
+// files into typed Go values, hiding the differences between file families
+// (tweets, likes, retweets, following) and between archive layouts
+// (an unpacked directory or the downloaded .zip itself) behind a common
+// loader shape.
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Tweet is a tweet in the Twitter archive JSON format.
+type Tweet struct {
+	ID                string   `json:"id_str"`
+	CreatedAt         string   `json:"created_at"`
+	Text              string   `json:"full_text"`
+	Retweeted         bool     `json:"retweeted"`
+	FavoriteCount     string   `json:"favorite_count"`
+	RetweetCount      string   `json:"retweet_count"`
+	InReplyToStatusID string   `json:"in_reply_to_status_id_str"`
+	Entities          Entities `json:"entities"`
+}
+
+// Entities holds the subset of a tweet's entities object LoadRetweets uses
+// to recover the original tweet ID of a retweet.
+type Entities struct {
+	URLs []URLEntity `json:"urls"`
+}
+
+// URLEntity is a single URL found in a tweet's entities.urls.
+type URLEntity struct {
+	ExpandedURL string `json:"expanded_url"`
+}
+
+// Like is a liked tweet recorded in data/like.js.
+type Like struct {
+	TweetID     string `json:"tweetId"`
+	FullText    string `json:"fullText"`
+	ExpandedURL string `json:"expandedUrl"`
+}
+
+// Retweet is a retweet found among the archive's tweets. The archive export
+// doesn't retain a dedicated field for the ID of the tweet that was
+// retweeted, but it can usually be recovered from the permalink entity
+// Twitter attaches to the retweet's text; see sourceTweetIDFromEntities.
+// SourceIDRecovered reports whether that recovery succeeded. When it
+// didn't, SourceTweetID falls back to the retweet's own ID, which is NOT
+// the original tweet and will not successfully unretweet anything via the
+// API — callers must surface that to the user rather than act on it
+// silently.
+type Retweet struct {
+	ID                string
+	SourceTweetID     string
+	SourceIDRecovered bool
+	CreatedAt         string
+}
+
+// Follow is an account followed by the user, recorded in data/following.js.
+type Follow struct {
+	AccountID string `json:"accountId"`
+	UserLink  string `json:"userLink"`
+}
+
+// source abstracts reading archive files by name (e.g. "data/like.js"),
+// so loaders don't need to know whether they're reading an unpacked
+// directory or streaming straight out of the downloaded .zip.
+type source interface {
+	// read returns the contents of name, or an error satisfying
+	// os.IsNotExist if it isn't present in the archive.
+	read(name string) ([]byte, error)
+	// tweetFileNames returns the names of every tweet data file present,
+	// across the formats Twitter has used over time.
+	tweetFileNames() []string
+	close() error
+}
+
+// openSource opens archivePath as a source, detecting whether it's an
+// unpacked archive directory or a .zip file straight off the download page.
+func openSource(archivePath string) (source, error) {
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open archive %s: %w", archivePath, err)
+	}
+	if info.IsDir() {
+		return dirSource{root: archivePath}, nil
+	}
+
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open %s as a Twitter archive zip: %w", archivePath, err)
+	}
+	return &zipSource{reader: zr}, nil
+}
+
+// dirSource reads files out of an unpacked archive directory.
+type dirSource struct {
+	root string
+}
+
+func (s dirSource) read(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(s.root, name))
+}
+
+func (s dirSource) tweetFileNames() []string {
+	var names []string
+
+	if _, err := os.Stat(filepath.Join(s.root, "data", "tweets.js")); err == nil {
+		names = append(names, "data/tweets.js")
+	}
+
+	tweetDataDir := filepath.Join(s.root, "data", "tweets")
+	if entries, err := ioutil.ReadDir(tweetDataDir); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".js") {
+				names = append(names, path.Join("data", "tweets", entry.Name()))
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		if _, err := os.Stat(filepath.Join(s.root, "data", "tweet.js")); err == nil {
+			names = append(names, "data/tweet.js")
+		}
+	}
+
+	return names
+}
+
+func (s dirSource) close() error {
+	return nil
+}
+
+// zipSource reads files straight out of a Twitter archive .zip, without
+// extracting it to disk first.
+type zipSource struct {
+	reader *zip.ReadCloser
+}
+
+func (s *zipSource) find(name string) *zip.File {
+	for _, f := range s.reader.File {
+		if f.Name == name || strings.TrimSuffix(f.Name, "/"+name) != f.Name {
+			return f
+		}
+	}
+	return nil
+}
+
+func (s *zipSource) read(name string) ([]byte, error) {
+	f := s.find(name)
+	if f == nil {
+		return nil, os.ErrNotExist
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return ioutil.ReadAll(rc)
+}
+
+func (s *zipSource) tweetFileNames() []string {
+	var names []string
+	for _, f := range s.reader.File {
+		name := path.Base(f.Name)
+		switch {
+		case name == "tweets.js":
+			names = append(names, f.Name)
+		case strings.HasPrefix(path.Dir(f.Name), "data/tweets") && strings.HasSuffix(name, ".js"):
+			names = append(names, f.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		for _, f := range s.reader.File {
+			if path.Base(f.Name) == "tweet.js" {
+				names = append(names, f.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+func (s *zipSource) close() error {
+	return s.reader.Close()
+}
+
+// stripYTDPrefix removes the "window.YTD.<family>.partN = " assignment
+// Twitter's archive export files wrap their JSON payload in.
+func stripYTDPrefix(content []byte) []byte {
+	if !bytes.HasPrefix(content, []byte("window.")) {
+		return content
+	}
+	parts := bytes.SplitN(content, []byte("= "), 2)
+	if len(parts) != 2 {
+		return content
+	}
+	return parts[1]
+}
+
+// loadYTDFile reads name from src, strips its window.YTD.* prefix, and
+// unmarshals the resulting JSON into out.
+func loadYTDFile(src source, name string, out interface{}) error {
+	content, err := src.read(name)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if err := json.Unmarshal(stripYTDPrefix(content), out); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// LoadTweets parses every tweet in the archive at archivePath, which may be
+// either an unpacked archive directory or the downloaded .zip itself.
+func LoadTweets(archivePath string) ([]Tweet, error) {
+	src, err := openSource(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer src.close()
+
+	names := src.tweetFileNames()
+	if len(names) == 0 {
+		return nil, fmt.Errorf("could not find tweet data files in %s", archivePath)
+	}
+
+	var tweets []Tweet
+	for _, name := range names {
+		var wrapped []struct {
+			Tweet Tweet `json:"tweet"`
+		}
+		if err := loadYTDFile(src, name, &wrapped); err != nil {
+			return nil, err
+		}
+		for _, w := range wrapped {
+			tweets = append(tweets, w.Tweet)
+		}
+	}
+
+	return tweets, nil
+}
+
+// LoadLikes parses every liked tweet in the archive at archivePath.
+func LoadLikes(archivePath string) ([]Like, error) {
+	src, err := openSource(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer src.close()
+
+	var wrapped []struct {
+		Like Like `json:"like"`
+	}
+	if err := loadYTDFile(src, "data/like.js", &wrapped); err != nil {
+		return nil, err
+	}
+
+	likes := make([]Like, 0, len(wrapped))
+	for _, w := range wrapped {
+		likes = append(likes, w.Like)
+	}
+	return likes, nil
+}
+
+// LoadRetweets returns every tweet in the archive that is a retweet.
+func LoadRetweets(archivePath string) ([]Retweet, error) {
+	tweets, err := LoadTweets(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var retweets []Retweet
+	for _, tweet := range tweets {
+		if !isRetweetText(tweet) {
+			continue
+		}
+		sourceID, ok := sourceTweetIDFromEntities(tweet.Entities)
+		if !ok {
+			sourceID = tweet.ID
+		}
+		retweets = append(retweets, Retweet{
+			ID:                tweet.ID,
+			SourceTweetID:     sourceID,
+			SourceIDRecovered: ok,
+			CreatedAt:         tweet.CreatedAt,
+		})
+	}
+	return retweets, nil
+}
+
+func isRetweetText(tweet Tweet) bool {
+	return tweet.Retweeted || strings.HasPrefix(tweet.Text, "RT @")
+}
+
+// statusURLPattern matches a tweet permalink, e.g.
+// "https://twitter.com/someone/status/123" or the x.com equivalent, and
+// captures the numeric status ID.
+var statusURLPattern = regexp.MustCompile(`(?i)^https?://(?:www\.)?(?:twitter|x)\.com/\w+/status(?:es)?/(\d+)`)
+
+// sourceTweetIDFromEntities looks for a tweet permalink among entities'
+// expanded URLs and, if found, returns the status ID it points at. Twitter
+// attaches the permalink of the retweeted tweet to a retweet's entities,
+// so this recovers the real source tweet ID when it's present.
+func sourceTweetIDFromEntities(entities Entities) (string, bool) {
+	for _, u := range entities.URLs {
+		if m := statusURLPattern.FindStringSubmatch(u.ExpandedURL); m != nil {
+			return m[1], true
+		}
+	}
+	return "", false
+}
+
+// LoadFollowing parses every followed account in the archive at archivePath.
+func LoadFollowing(archivePath string) ([]Follow, error) {
+	src, err := openSource(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer src.close()
+
+	var wrapped []struct {
+		Following Follow `json:"following"`
+	}
+	if err := loadYTDFile(src, "data/following.js", &wrapped); err != nil {
+		return nil, err
+	}
+
+	follows := make([]Follow, 0, len(wrapped))
+	for _, w := range wrapped {
+		follows = append(follows, w.Following)
+	}
+	return follows, nil
+}
@@ -0,0 +1,161 @@
+package archive
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeArchiveFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, "data"), 0o755); err != nil {
+		t.Fatalf("failed to create data dir: %v", err)
+	}
+	path := filepath.Join(dir, "data", name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestLoadTweets(t *testing.T) {
+	dir := t.TempDir()
+	writeArchiveFile(t, dir, "tweets.js", `window.YTD.tweets.part0 = [
+		{"tweet": {"id_str": "1", "full_text": "hello", "created_at": "Sat Jun 15 00:00:00 +0000 2026"}},
+		{"tweet": {"id_str": "2", "full_text": "RT @someone: hi", "created_at": "Sat Jun 15 00:00:00 +0000 2026"}}
+	]`)
+
+	tweets, err := LoadTweets(dir)
+	if err != nil {
+		t.Fatalf("LoadTweets() error = %v", err)
+	}
+	if len(tweets) != 2 {
+		t.Fatalf("got %d tweets, want 2", len(tweets))
+	}
+	if tweets[0].ID != "1" || tweets[0].Text != "hello" {
+		t.Errorf("unexpected first tweet: %+v", tweets[0])
+	}
+}
+
+func writeArchiveZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "archive.zip")
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create %s: %v", path, err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to add %s to zip: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write %s to zip: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip writer: %v", err)
+	}
+
+	return path
+}
+
+func TestLoadTweetsFromZip(t *testing.T) {
+	zipPath := writeArchiveZip(t, map[string]string{
+		"data/tweets.js": `window.YTD.tweets.part0 = [
+			{"tweet": {"id_str": "1", "full_text": "hello"}}
+		]`,
+	})
+
+	tweets, err := LoadTweets(zipPath)
+	if err != nil {
+		t.Fatalf("LoadTweets() error = %v", err)
+	}
+	if len(tweets) != 1 || tweets[0].ID != "1" {
+		t.Errorf("got %+v, want a single tweet with ID 1", tweets)
+	}
+}
+
+func TestLoadLikesFromZip(t *testing.T) {
+	zipPath := writeArchiveZip(t, map[string]string{
+		"data/like.js": `window.YTD.like.part0 = [
+			{"like": {"tweetId": "42"}}
+		]`,
+	})
+
+	likes, err := LoadLikes(zipPath)
+	if err != nil {
+		t.Fatalf("LoadLikes() error = %v", err)
+	}
+	if len(likes) != 1 || likes[0].TweetID != "42" {
+		t.Errorf("got %+v, want a single like with tweetId 42", likes)
+	}
+}
+
+func TestLoadTweetsMissing(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadTweets(dir); err == nil {
+		t.Fatal("expected an error when no tweet data files exist")
+	}
+}
+
+func TestLoadLikes(t *testing.T) {
+	dir := t.TempDir()
+	writeArchiveFile(t, dir, "like.js", `window.YTD.like.part0 = [
+		{"like": {"tweetId": "42", "fullText": "liked this"}}
+	]`)
+
+	likes, err := LoadLikes(dir)
+	if err != nil {
+		t.Fatalf("LoadLikes() error = %v", err)
+	}
+	if len(likes) != 1 || likes[0].TweetID != "42" {
+		t.Errorf("got %+v, want a single like with tweetId 42", likes)
+	}
+}
+
+func TestLoadRetweets(t *testing.T) {
+	dir := t.TempDir()
+	writeArchiveFile(t, dir, "tweets.js", `window.YTD.tweets.part0 = [
+		{"tweet": {"id_str": "1", "full_text": "original tweet"}},
+		{"tweet": {"id_str": "2", "full_text": "RT @someone: shared", "entities": {"urls": [
+			{"expanded_url": "https://twitter.com/someone/status/987654"}
+		]}}},
+		{"tweet": {"id_str": "3", "full_text": "RT @another: shared with no permalink"}}
+	]`)
+
+	retweets, err := LoadRetweets(dir)
+	if err != nil {
+		t.Fatalf("LoadRetweets() error = %v", err)
+	}
+	if len(retweets) != 2 {
+		t.Fatalf("got %d retweets, want 2", len(retweets))
+	}
+
+	if got := retweets[0]; got.ID != "2" || got.SourceTweetID != "987654" || !got.SourceIDRecovered {
+		t.Errorf("got %+v, want retweet ID 2 recovered with source tweet ID 987654", got)
+	}
+
+	if got := retweets[1]; got.ID != "3" || got.SourceTweetID != "3" || got.SourceIDRecovered {
+		t.Errorf("got %+v, want retweet ID 3 falling back to its own ID, unrecovered", got)
+	}
+}
+
+func TestLoadFollowing(t *testing.T) {
+	dir := t.TempDir()
+	writeArchiveFile(t, dir, "following.js", `window.YTD.following.part0 = [
+		{"following": {"accountId": "123"}}
+	]`)
+
+	follows, err := LoadFollowing(dir)
+	if err != nil {
+		t.Fatalf("LoadFollowing() error = %v", err)
+	}
+	if len(follows) != 1 || follows[0].AccountID != "123" {
+		t.Errorf("got %+v, want a single follow with accountId 123", follows)
+	}
+}
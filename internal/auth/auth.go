@@ -0,0 +1,239 @@
+// Package auth implements Twitter's OAuth 2.0 Authorization Code with PKCE
+// flow, as an alternative to supplying OAuth 1.0a consumer/access keys on
+// every invocation. A completed login is persisted to an OS-appropriate
+// config file and silently refreshed as needed.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"golang.org/x/oauth2"
+)
+
+// authURL and tokenURL are Twitter's OAuth 2.0 endpoints.
+const (
+	authURL  = "https://twitter.com/i/oauth2/authorize"
+	tokenURL = "https://api.twitter.com/2/oauth2/token"
+)
+
+// scopes requests everything go-tweet-cleaner's subcommands need: reading
+// the authenticated user's ID, and deleting tweets, likes, retweets, and
+// follows. offline.access allows the token to be refreshed after it expires.
+var scopes = []string{
+	"tweet.read", "tweet.write", "users.read",
+	"like.read", "like.write",
+	"follows.read", "follows.write",
+	"offline.access",
+}
+
+// Token is the persisted result of a completed login. It embeds
+// oauth2.Token and additionally records the client ID it was issued to,
+// which is needed again to refresh it.
+type Token struct {
+	oauth2.Token
+	ClientID string `json:"client_id"`
+}
+
+// endpoint builds the oauth2.Config shared by login and refresh. Twitter's
+// token endpoint expects the client ID in the POST body rather than via
+// HTTP Basic Auth, which is how public (PKCE, no client secret) clients
+// authenticate.
+func endpoint(clientID string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID: clientID,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   authURL,
+			TokenURL:  tokenURL,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		Scopes: scopes,
+	}
+}
+
+// TokenPath returns the path go-tweet-cleaner stores its OAuth2 token at:
+// $XDG_CONFIG_HOME/go-tweet-cleaner/token.json, falling back to
+// ~/.config/go-tweet-cleaner/token.json.
+func TokenPath() (string, error) {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve home directory: %w", err)
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configDir, "go-tweet-cleaner", "token.json"), nil
+}
+
+// LoadToken reads the token saved by a previous `auth login`. ok is false,
+// with a nil error, if no one has ever logged in.
+func LoadToken() (*Token, bool, error) {
+	path, err := TokenPath()
+	if err != nil {
+		return nil, false, err
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var tok Token
+	if err := json.Unmarshal(content, &tok); err != nil {
+		return nil, false, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &tok, true, nil
+}
+
+// SaveToken persists tok to TokenPath, creating its parent directory if
+// needed.
+func SaveToken(tok *Token) error {
+	path, err := TokenPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	content, err := json.MarshalIndent(tok, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode token: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Login runs the OAuth 2.0 Authorization Code with PKCE flow for clientID:
+// it prints a consent URL and opens the user's browser to it, receives the
+// authorization code on a localhost callback server, and exchanges it for
+// a token.
+func Login(ctx context.Context, clientID string) (*Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start callback listener: %w", err)
+	}
+	defer listener.Close()
+
+	config := endpoint(clientID)
+	config.RedirectURL = fmt.Sprintf("http://127.0.0.1:%d/callback", listener.Addr().(*net.TCPAddr).Port)
+
+	state, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	type callbackResult struct {
+		code string
+		err  error
+	}
+	results := make(chan callbackResult, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		if reason := r.URL.Query().Get("error"); reason != "" {
+			results <- callbackResult{err: fmt.Errorf("authorization denied: %s", reason)}
+			fmt.Fprintln(w, "Authorization denied. You can close this tab.")
+			return
+		}
+		if r.URL.Query().Get("state") != state {
+			results <- callbackResult{err: fmt.Errorf("state mismatch in OAuth2 callback")}
+			fmt.Fprintln(w, "Login failed: state mismatch. You can close this tab.")
+			return
+		}
+		results <- callbackResult{code: r.URL.Query().Get("code")}
+		fmt.Fprintln(w, "Login complete. You can close this tab.")
+	})
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authCodeURL := config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier))
+	fmt.Printf("Opening your browser to authorize go-tweet-cleaner:\n%s\n", authCodeURL)
+	openBrowser(authCodeURL)
+
+	var result callbackResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	oauthTok, err := config.Exchange(ctx, result.code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	return &Token{Token: *oauthTok, ClientID: clientID}, nil
+}
+
+// persistingTokenSource wraps an oauth2.TokenSource, saving every token it
+// hands out so a refresh performed mid-command isn't lost.
+type persistingTokenSource struct {
+	base     oauth2.TokenSource
+	clientID string
+}
+
+func (s *persistingTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+	if err := SaveToken(&Token{Token: *tok, ClientID: s.clientID}); err != nil {
+		fmt.Printf("Warning: failed to persist refreshed OAuth2 token: %v\n", err)
+	}
+	return tok, nil
+}
+
+// HTTPClient returns an *http.Client that authenticates requests with tok,
+// transparently refreshing and re-persisting it once it expires.
+func HTTPClient(ctx context.Context, tok *Token) *http.Client {
+	source := &persistingTokenSource{
+		base:     endpoint(tok.ClientID).TokenSource(ctx, &tok.Token),
+		clientID: tok.ClientID,
+	}
+	return oauth2.NewClient(ctx, source)
+}
+
+// randomString returns a URL-safe random string built from n bytes of
+// entropy, used as the OAuth2 state parameter.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// openBrowser best-effort opens url in the user's default browser.
+func openBrowser(url string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	_ = cmd.Start()
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestTokenPathRespectsXDGConfigHome(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/config")
+
+	path, err := TokenPath()
+	if err != nil {
+		t.Fatalf("TokenPath() error = %v", err)
+	}
+	want := filepath.Join("/config", "go-tweet-cleaner", "token.json")
+	if path != want {
+		t.Errorf("TokenPath() = %q, want %q", path, want)
+	}
+}
+
+func TestSaveAndLoadToken(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	tok := &Token{
+		Token:    oauth2.Token{AccessToken: "access", RefreshToken: "refresh"},
+		ClientID: "client-123",
+	}
+	if err := SaveToken(tok); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+
+	got, ok, err := LoadToken()
+	if err != nil {
+		t.Fatalf("LoadToken() error = %v", err)
+	}
+	if !ok {
+		t.Fatal("LoadToken() ok = false, want true after SaveToken")
+	}
+	if got.AccessToken != tok.AccessToken || got.RefreshToken != tok.RefreshToken || got.ClientID != tok.ClientID {
+		t.Errorf("got %+v, want %+v", got, tok)
+	}
+}
+
+func TestLoadTokenNoFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	_, ok, err := LoadToken()
+	if err != nil {
+		t.Fatalf("LoadToken() error = %v", err)
+	}
+	if ok {
+		t.Fatal("LoadToken() ok = true, want false when nobody has logged in")
+	}
+}
+
+func TestRandomStringIsURLSafeAndUnique(t *testing.T) {
+	a, err := randomString(32)
+	if err != nil {
+		t.Fatalf("randomString() error = %v", err)
+	}
+	b, err := randomString(32)
+	if err != nil {
+		t.Fatalf("randomString() error = %v", err)
+	}
+
+	if a == b {
+		t.Fatal("randomString() returned the same value twice, want distinct state values")
+	}
+	for _, r := range a {
+		if r == '+' || r == '/' || r == '=' {
+			t.Fatalf("randomString() = %q, want URL-safe, unpadded encoding", a)
+		}
+	}
+}
+
+func TestEndpointUsesPKCEFriendlyAuthStyle(t *testing.T) {
+	config := endpoint("client-123")
+
+	if config.ClientID != "client-123" {
+		t.Errorf("ClientID = %q, want %q", config.ClientID, "client-123")
+	}
+	if config.Endpoint.AuthStyle != oauth2.AuthStyleInParams {
+		t.Errorf("AuthStyle = %v, want AuthStyleInParams, since public PKCE clients have no secret to send via Basic Auth", config.Endpoint.AuthStyle)
+	}
+	if config.Endpoint.AuthURL != authURL || config.Endpoint.TokenURL != tokenURL {
+		t.Errorf("got auth/token URLs %q/%q, want %q/%q", config.Endpoint.AuthURL, config.Endpoint.TokenURL, authURL, tokenURL)
+	}
+}
+
+func TestAuthCodeURLCarriesStateAndPKCEChallenge(t *testing.T) {
+	config := endpoint("client-123")
+	config.RedirectURL = "http://127.0.0.1:1/callback"
+
+	verifier := oauth2.GenerateVerifier()
+	authCodeURL := config.AuthCodeURL("the-state", oauth2.S256ChallengeOption(verifier))
+
+	if !strings.Contains(authCodeURL, "state=the-state") {
+		t.Errorf("AuthCodeURL() = %q, want it to carry the state parameter", authCodeURL)
+	}
+	if !strings.Contains(authCodeURL, "code_challenge=") || !strings.Contains(authCodeURL, "code_challenge_method=S256") {
+		t.Errorf("AuthCodeURL() = %q, want a PKCE S256 code challenge", authCodeURL)
+	}
+}
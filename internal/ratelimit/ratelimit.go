@@ -0,0 +1,119 @@
+// Package ratelimit tracks Twitter's per-endpoint rate limit state from
+// response headers and provides backoff helpers for transient failures.
+package ratelimit
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitError describes a request rejected by Twitter's rate limiter,
+// distinguishing 429 responses from other kinds of failure.
+type RateLimitError struct {
+	Remaining  int
+	Reset      time.Time
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited: remaining=%d reset=%s retry-after=%s", e.Remaining, e.Reset.Format(time.RFC3339), e.RetryAfter)
+}
+
+// RateLimiter tracks the most recently observed rate limit state for an
+// endpoint and blocks callers when the limit has been exhausted. It is
+// safe for concurrent use so a pool of workers can share one instance.
+type RateLimiter struct {
+	mu        sync.Mutex
+	remaining int
+	reset     time.Time
+	hasState  bool
+}
+
+// New returns a RateLimiter with no known state; the first response updates it.
+func New() *RateLimiter {
+	return &RateLimiter{remaining: -1}
+}
+
+// Update records the rate limit state reported by resp's
+// x-rate-limit-remaining and x-rate-limit-reset headers.
+func (r *RateLimiter) Update(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if v := resp.Header.Get("x-rate-limit-remaining"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			r.remaining = n
+			r.hasState = true
+		}
+	}
+	if v := resp.Header.Get("x-rate-limit-reset"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			r.reset = time.Unix(n, 0)
+		}
+	}
+}
+
+// Wait blocks until it is safe to send another request. If the last
+// observed state had no remaining requests, it sleeps until the reset time.
+func (r *RateLimiter) Wait() {
+	r.mu.Lock()
+	remaining, reset, hasState := r.remaining, r.reset, r.hasState
+	r.mu.Unlock()
+
+	if !hasState || remaining > 0 {
+		return
+	}
+
+	if wait := time.Until(reset); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// FromResponse returns a RateLimitError describing resp if it is a 429,
+// parsing the Retry-After and x-rate-limit-reset headers, or nil otherwise.
+func FromResponse(resp *http.Response) *RateLimitError {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+
+	rlErr := &RateLimitError{}
+
+	if v := resp.Header.Get("x-rate-limit-reset"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			rlErr.Reset = time.Unix(n, 0)
+		}
+	}
+
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			rlErr.RetryAfter = time.Duration(secs) * time.Second
+		}
+	}
+
+	if rlErr.RetryAfter <= 0 && !rlErr.Reset.IsZero() {
+		rlErr.RetryAfter = time.Until(rlErr.Reset)
+	}
+
+	return rlErr
+}
+
+// Backoff returns an exponential backoff duration for the given (0-indexed)
+// attempt, doubling from base and capped at max, with up to 50% jitter to
+// avoid every worker retrying in lockstep.
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	d := base * time.Duration(uint(1)<<uint(attempt))
+	if d <= 0 || d > max {
+		d = max
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(d)/2 + 1))
+	return d/2 + jitter
+}
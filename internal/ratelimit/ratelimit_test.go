@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestFromResponse(t *testing.T) {
+	if got := FromResponse(&http.Response{StatusCode: http.StatusOK}); got != nil {
+		t.Errorf("FromResponse() = %v, want nil for a non-429 response", got)
+	}
+
+	reset := time.Now().Add(30 * time.Second)
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"Retry-After":        []string{"5"},
+			"X-Rate-Limit-Reset": []string{strconv.FormatInt(reset.Unix(), 10)},
+		},
+	}
+
+	rlErr := FromResponse(resp)
+	if rlErr == nil {
+		t.Fatal("FromResponse() = nil, want a RateLimitError for a 429")
+	}
+	if rlErr.RetryAfter != 5*time.Second {
+		t.Errorf("RetryAfter = %v, want 5s", rlErr.RetryAfter)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := New()
+
+	// No state observed yet: Wait must return immediately.
+	start := time.Now()
+	limiter.Wait()
+	if time.Since(start) > 50*time.Millisecond {
+		t.Fatal("Wait() blocked with no observed rate limit state")
+	}
+
+	reset := time.Now().Add(2 * time.Second)
+	resp := &http.Response{
+		Header: http.Header{
+			"X-Rate-Limit-Remaining": []string{"0"},
+			"X-Rate-Limit-Reset":     []string{strconv.FormatInt(reset.Unix(), 10)},
+		},
+	}
+	limiter.Update(resp)
+
+	start = time.Now()
+	limiter.Wait()
+	if time.Since(start) < 500*time.Millisecond {
+		t.Fatal("Wait() returned before the reset time elapsed")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	max := 10 * time.Second
+	for attempt := 0; attempt < 5; attempt++ {
+		d := Backoff(attempt, time.Second, max)
+		if d <= 0 || d > max {
+			t.Errorf("Backoff(%d) = %v, want in (0, %v]", attempt, d, max)
+		}
+	}
+}
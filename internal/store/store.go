@@ -0,0 +1,147 @@
+// Package store persists the deletion status of each archive tweet in a
+// SQLite database so a job can be inspected or resumed after it stops.
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Status is the deletion status of a single tweet.
+type Status string
+
+// The set of statuses a tweet can be in over the lifetime of a job.
+const (
+	StatusPending     Status = "pending"
+	StatusDeleted     Status = "deleted"
+	StatusAlreadyGone Status = "already-gone"
+	StatusFailed      Status = "failed"
+	StatusSkipped     Status = "skipped"
+)
+
+// Record is a tweet's current status as recorded in the store.
+type Record struct {
+	ID        string
+	Status    Status
+	Reason    string
+	UpdatedAt string
+}
+
+// Store records tweet deletion status in a SQLite database keyed by tweet ID.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the SQLite database at path and ensures
+// its schema is in place.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state database %q: %w", path, err)
+	}
+
+	s := &Store{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Store) migrate() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS tweets (
+			id TEXT PRIMARY KEY,
+			status TEXT NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create tweets table: %w", err)
+	}
+	return nil
+}
+
+// Close closes the underlying database connection.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Upsert records status (and an optional reason, e.g. an error message) for
+// the tweet with the given ID.
+func (s *Store) Upsert(id string, status Status, reason string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO tweets (id, status, reason, updated_at)
+		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(id) DO UPDATE SET status = excluded.status, reason = excluded.reason, updated_at = excluded.updated_at
+	`, id, string(status), reason)
+	if err != nil {
+		return fmt.Errorf("failed to upsert status for tweet %s: %w", id, err)
+	}
+	return nil
+}
+
+// Get returns the recorded status for id, and false if no row exists yet.
+func (s *Store) Get(id string) (Record, bool, error) {
+	row := s.db.QueryRow(`SELECT id, status, reason, updated_at FROM tweets WHERE id = ?`, id)
+
+	var rec Record
+	var status string
+	if err := row.Scan(&rec.ID, &status, &rec.Reason, &rec.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return Record{}, false, nil
+		}
+		return Record{}, false, fmt.Errorf("failed to look up tweet %s: %w", id, err)
+	}
+	rec.Status = Status(status)
+
+	return rec, true, nil
+}
+
+// All returns every recorded tweet, ordered by ID.
+func (s *Store) All() ([]Record, error) {
+	rows, err := s.db.Query(`SELECT id, status, reason, updated_at FROM tweets ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tweets: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRecords(rows)
+}
+
+// Unresolved returns every recorded tweet that is not yet deleted or
+// already-gone, i.e. the ones a resumed job still has to act on.
+func (s *Store) Unresolved() ([]Record, error) {
+	rows, err := s.db.Query(`
+		SELECT id, status, reason, updated_at FROM tweets
+		WHERE status NOT IN (?, ?)
+		ORDER BY id
+	`, string(StatusDeleted), string(StatusAlreadyGone))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unresolved tweets: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRecords(rows)
+}
+
+func scanRecords(rows *sql.Rows) ([]Record, error) {
+	var records []Record
+	for rows.Next() {
+		var rec Record
+		var status string
+		if err := rows.Scan(&rec.ID, &status, &rec.Reason, &rec.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tweet row: %w", err)
+		}
+		rec.Status = Status(status)
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tweet rows: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	s, err := Open(filepath.Join(t.TempDir(), "tweets.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	return s
+}
+
+func TestUpsertAndGet(t *testing.T) {
+	s := openTestStore(t)
+
+	if _, ok, err := s.Get("1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	} else if ok {
+		t.Fatal("expected no record for an unknown tweet")
+	}
+
+	if err := s.Upsert("1", StatusPending, ""); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	rec, ok, err := s.Get("1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a record after Upsert")
+	}
+	if rec.Status != StatusPending {
+		t.Errorf("Status = %v, want %v", rec.Status, StatusPending)
+	}
+
+	if err := s.Upsert("1", StatusFailed, "rate limited"); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	rec, _, err = s.Get("1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if rec.Status != StatusFailed || rec.Reason != "rate limited" {
+		t.Errorf("got %+v, want status=%v reason=%q", rec, StatusFailed, "rate limited")
+	}
+}
+
+func TestUnresolved(t *testing.T) {
+	s := openTestStore(t)
+
+	for id, status := range map[string]Status{
+		"1": StatusDeleted,
+		"2": StatusPending,
+		"3": StatusFailed,
+		"4": StatusAlreadyGone,
+	} {
+		if err := s.Upsert(id, status, ""); err != nil {
+			t.Fatalf("Upsert(%s) error = %v", id, err)
+		}
+	}
+
+	records, err := s.Unresolved()
+	if err != nil {
+		t.Fatalf("Unresolved() error = %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, rec := range records {
+		got[rec.ID] = true
+	}
+	if len(got) != 2 || !got["2"] || !got["3"] {
+		t.Errorf("Unresolved() = %+v, want tweets 2 and 3", records)
+	}
+}